@@ -0,0 +1,64 @@
+package api
+
+import (
+	"sync"
+)
+
+// subscriberHub fans out broadcast messages to per-connection channels, grouped by topic.
+type subscriberHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+// newSubscriberHub creates an empty hub ready to register subscribers.
+func newSubscriberHub() *subscriberHub {
+	return &subscriberHub{subscribers: make(map[string]map[chan []byte]struct{})}
+}
+
+// Register creates and returns a new subscriber channel for the given topic.
+func (h *subscriberHub) Register(topic string) chan []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan []byte, 16)
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	h.subscribers[topic][ch] = struct{}{}
+
+	return ch
+}
+
+// Unregister removes and closes a subscriber channel for the given topic.
+func (h *subscriberHub) Unregister(topic string, ch chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subscribers[topic]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(h.subscribers, topic)
+	}
+}
+
+// Broadcast sends a message to every subscriber registered on the given topic. A subscriber whose
+// buffer is full misses this message rather than blocking the pump; it stays registered.
+func (h *subscriberHub) Broadcast(topic string, message []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[topic] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+}
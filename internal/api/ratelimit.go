@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitConfig describes the quota for a single route: limit/window back the Redis fixed-window
+// counter shared across replicas, while rps/burst size the in-memory rate.Limiter used as a
+// fallback when Redis is unavailable.
+type rateLimitConfig struct {
+	limit  int64
+	window time.Duration
+	rps    rate.Limit
+	burst  int
+}
+
+// routeRateLimits are PUBG's upstream API is quota-limited, so these keep a single abusive client
+// from exhausting it through this service.
+var routeRateLimits = map[string]rateLimitConfig{
+	"/current-leaderboard":    {limit: 10, window: time.Second, rps: 10, burst: 20},
+	"/player-stats/:playerID": {limit: 5, window: time.Second, rps: 5, burst: 10},
+	"/backup-leaderboard":     {limit: 1, window: time.Minute, rps: rate.Limit(1.0 / 60), burst: 1},
+}
+
+// localLimiterIdleTTL bounds how long an idle fallback limiter is kept. Without this, a sustained
+// Redis outage combined with many distinct clientIPs/playerIDs (both attacker-influenced) would
+// grow the fallback map without bound for the life of the process.
+const localLimiterIdleTTL = 10 * time.Minute
+
+// localLimiterEntry pairs a fallback limiter with the last time it was used, so idle entries can
+// be swept.
+type localLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// localLimiters holds the in-memory rate.Limiter fallback, keyed by "route:clientKey".
+type localLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*localLimiterEntry
+}
+
+func newLocalLimiters() *localLimiters {
+	l := &localLimiters{limiters: make(map[string]*localLimiterEntry)}
+	go l.sweepIdle()
+
+	return l
+}
+
+func (l *localLimiters) get(key string, cfg rateLimitConfig) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &localLimiterEntry{limiter: rate.NewLimiter(cfg.rps, cfg.burst)}
+		l.limiters[key] = entry
+	}
+	entry.lastUsed = time.Now()
+
+	return entry.limiter
+}
+
+// sweepIdle periodically evicts fallback limiters untouched for localLimiterIdleTTL.
+func (l *localLimiters) sweepIdle() {
+	ticker := time.NewTicker(localLimiterIdleTTL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-localLimiterIdleTTL)
+
+		l.mu.Lock()
+		for key, entry := range l.limiters {
+			if entry.lastUsed.Before(cutoff) {
+				delete(l.limiters, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// rateLimitMiddleware enforces route's configured quota per clientKey(c). The bucket state lives
+// in Redis (INCR key EX window) so the limit holds across replicas; if Redis is unavailable it
+// falls back to an in-memory rate.Limiter scoped to this instance only.
+func (s *Server) rateLimitMiddleware(route string, clientKey func(c *gin.Context) string) gin.HandlerFunc {
+	cfg := routeRateLimits[route]
+	fallback := newLocalLimiters()
+
+	return func(c *gin.Context) {
+		key := clientKey(c)
+
+		redisKey := fmt.Sprintf("ratelimit:%s:%s", route, key)
+		remaining, retryAfter, err := s.redisClient.AllowRequest(c.Request.Context(), redisKey, cfg.limit, cfg.window)
+		if err != nil {
+			s.logger.WithError(err).Warn("Rate limiter: Redis unavailable, falling back to in-memory limiter")
+			if !fallback.get(route+":"+key, cfg).Allow() {
+				c.Header("Retry-After", "1")
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		if remaining < 0 {
+			c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientIPKey rate-limits by the requesting client's IP address.
+func clientIPKey(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// playerIDKey rate-limits by the playerID path parameter.
+func playerIDKey(c *gin.Context) string {
+	return c.Param("playerID")
+}
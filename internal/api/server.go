@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gbasileGP/pubg-leaderboard/internal/store"
@@ -18,6 +21,7 @@ type Server struct {
 	redisClient        *store.RedisClient
 	leaderboardService *service.LeaderboardService
 	logger             *logrus.Logger
+	hub                *subscriberHub
 }
 
 // NewServer initializes a new server with configured Redis client, leaderboard service, and logger passed from main.
@@ -29,20 +33,74 @@ func NewServer(redisClient *store.RedisClient, leaderboardService *service.Leade
 		redisClient:        redisClient,
 		leaderboardService: leaderboardService,
 		logger:             logger,
+		hub:                newSubscriberHub(),
 	}
 	server.setupRoutes()
 
+	// Mirror every archived season to object storage via the existing backup path, so season
+	// history survives even if the Redis archive keys are ever lost.
+	redisClient.SetSeasonBackup(func(ctx context.Context, seasonID string) error {
+		return leaderboardService.BackupLeaderboardData(ctx, "pubg-leaderboard", fmt.Sprintf("season_%s_archive.json", seasonID))
+	})
+
+	go server.pumpRedisEvents()
+
 	return server
 }
 
+// sseEvent is the JSON frame shape sent to every SSE subscriber, whether the update covers the
+// whole leaderboard or a single player.
+type sseEvent struct {
+	Type      string `json:"type"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+	PlayerID  string `json:"playerID,omitempty"`
+}
+
+// pumpRedisEvents relays leaderboard and player-stat update notifications from Redis pub/sub into
+// the in-process subscriber hub, so every SSE connection on this instance sees changes published by
+// any replica. Leaderboard-wide updates go to the "leaderboard" topic; per-player updates are
+// scoped to that player's own "player:<id>" topic so a player subscription doesn't fire on every
+// other player's write.
+func (s *Server) pumpRedisEvents() {
+	events := s.redisClient.Subscribe(context.Background(), store.LeaderboardUpdatedChannel, store.CacheInvalidationChannel)
+	for event := range events {
+		switch event.Channel {
+		case store.LeaderboardUpdatedChannel:
+			payload, err := json.Marshal(sseEvent{Type: "leaderboard.updated", UpdatedAt: event.Payload})
+			if err != nil {
+				s.logger.WithError(err).Error("API: Failed to marshal leaderboard SSE event")
+				continue
+			}
+			s.hub.Broadcast("leaderboard", payload)
+		case store.CacheInvalidationChannel:
+			playerID, ok := strings.CutPrefix(event.Payload, "player_stats:")
+			if !ok {
+				continue
+			}
+			payload, err := json.Marshal(sseEvent{Type: "player.updated", PlayerID: playerID})
+			if err != nil {
+				s.logger.WithError(err).Error("API: Failed to marshal player SSE event")
+				continue
+			}
+			s.hub.Broadcast("player:"+playerID, payload)
+		}
+	}
+}
+
 // setupRoutes defines all the routes for the server.
 func (s *Server) setupRoutes() {
 	s.router.GET("/ping", s.handlePing)
 	s.router.GET("/redis-ping", s.handleRedisPing)
 	s.router.GET("/current-season", s.handleGetCurrentSeason)
-	s.router.GET("/current-leaderboard", s.handleGetCurrentLeaderboard)
-	s.router.GET("/player-stats/:playerID", s.handleGetPlayerStats)
-	s.router.POST("/backup-leaderboard", s.handleBackupLeaderboard)
+	s.router.GET("/current-leaderboard", s.rateLimitMiddleware("/current-leaderboard", clientIPKey), s.handleGetCurrentLeaderboard)
+	s.router.GET("/player-stats/:playerID", s.rateLimitMiddleware("/player-stats/:playerID", playerIDKey), s.handleGetPlayerStats)
+	s.router.GET("/player-stats/:playerID/around", s.handleGetPlayerAround)
+	s.router.GET("/leaderboard", s.handleGetLeaderboardRange)
+	s.router.GET("/subscribe/leaderboard", s.handleSubscribeLeaderboard)
+	s.router.GET("/subscribe/player/:playerID", s.handleSubscribePlayer)
+	s.router.GET("/seasons", s.handleListSeasons)
+	s.router.GET("/seasons/:seasonID/leaderboard", s.handleGetArchivedLeaderboard)
+	s.router.POST("/backup-leaderboard", s.rateLimitMiddleware("/backup-leaderboard", clientIPKey), s.handleBackupLeaderboard)
 	s.router.POST("/restore-leaderboard", s.handleRestoreLeaderboard)
 }
 
@@ -110,6 +168,128 @@ func (s *Server) handleGetPlayerStats(c *gin.Context) {
 	})
 }
 
+// handleGetLeaderboardRange is a handler for fetching a page of the leaderboard, ranked by score.
+func (s *Server) handleGetLeaderboardRange(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+
+	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	entries, err := s.redisClient.GetLeaderboardRange(c.Request.Context(), offset, offset+limit-1)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get leaderboard range")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get leaderboard range"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"offset": offset, "limit": limit, "entries": entries})
+}
+
+// handleGetPlayerAround is a handler for fetching the leaderboard entries surrounding a given player.
+func (s *Server) handleGetPlayerAround(c *gin.Context) {
+	playerID := c.Param("playerID")
+
+	radius, err := strconv.ParseInt(c.DefaultQuery("radius", "5"), 10, 64)
+	if err != nil || radius <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radius"})
+		return
+	}
+
+	entries, err := s.redisClient.GetAroundPlayer(c.Request.Context(), playerID, radius)
+	if err != nil {
+		if err == store.ErrCacheMiss {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player stats not found"})
+		} else {
+			s.logger.WithError(err).Error("Failed to get leaderboard around player")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get leaderboard around player"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"playerID": playerID, "radius": radius, "entries": entries})
+}
+
+// handleSubscribeLeaderboard streams leaderboard update notifications as Server-Sent Events.
+func (s *Server) handleSubscribeLeaderboard(c *gin.Context) {
+	s.streamEvents(c, "leaderboard")
+}
+
+// handleSubscribePlayer streams update notifications for a single player as Server-Sent Events.
+func (s *Server) handleSubscribePlayer(c *gin.Context) {
+	playerID := c.Param("playerID")
+	s.streamEvents(c, "player:"+playerID)
+}
+
+// streamEvents upgrades the response to text/event-stream and relays hub messages for topic to the
+// client until it disconnects.
+func (s *Server) streamEvents(c *gin.Context, topic string) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Streaming unsupported"})
+		return
+	}
+
+	ch := s.hub.Register(topic)
+	defer s.hub.Unregister(topic, ch)
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-ch:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(c.Writer, "data: %s\n\n", message)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleListSeasons is a handler for listing every archived season.
+func (s *Server) handleListSeasons(c *gin.Context) {
+	seasons, err := s.redisClient.ListSeasons(c.Request.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list archived seasons")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list archived seasons"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"seasons": seasons})
+}
+
+// handleGetArchivedLeaderboard is a handler for fetching a previously archived season's leaderboard.
+func (s *Server) handleGetArchivedLeaderboard(c *gin.Context) {
+	seasonID := c.Param("seasonID")
+
+	leaderboardData, err := s.redisClient.GetArchivedLeaderboard(c.Request.Context(), seasonID)
+	if err != nil {
+		if err == store.ErrCacheMiss {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Archived season not found"})
+		} else {
+			s.logger.WithError(err).Error("Failed to get archived leaderboard")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get archived leaderboard"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboardData)
+}
+
 // handleBackupLeaderboard handles the request to backup the current leaderboard.
 func (s *Server) handleBackupLeaderboard(c *gin.Context) {
 	bucketName := "pubg-leaderboard" // This can be a query param or can be set in the config/env.
@@ -125,24 +305,29 @@ func (s *Server) handleBackupLeaderboard(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Leaderboard data backed up successfully", "bucket": bucketName, "file": backupFileName})
 }
 
-// handleRestoreLeaderboard handles the request to restore the leaderboard from a backup.
+// handleRestoreLeaderboard handles the request to restore the leaderboard from a backup. An
+// optional seasonID hydrates the backup into that season's archive instead of the live
+// leaderboard, so an operator can inspect a historical season without overwriting the current one.
 func (s *Server) handleRestoreLeaderboard(c *gin.Context) {
 	bucketName := "pubg-leaderboard"  // This can be a query param or can be set in the config/env.
 	backupFileName := c.Query("file") // The name of the backup file to restore from.
+	seasonID := c.Query("seasonID")   // Optional: restore into this season's archive instead of live.
 
 	if backupFileName == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Backup file name is required"})
 		return
 	}
 
-	err := s.leaderboardService.RestoreLeaderboardData(c.Request.Context(), bucketName, backupFileName)
+	// RestoreLeaderboardData honors seasonID itself: when set, it hydrates the backup straight into
+	// that season's archive key and never touches the live "leaderboard" key.
+	err := s.leaderboardService.RestoreLeaderboardData(c.Request.Context(), bucketName, backupFileName, seasonID)
 	if err != nil {
 		s.logger.WithError(err).Error("API: Failed to restore leaderboard data")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore leaderboard data"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Leaderboard data restored successfully", "bucket": bucketName, "file": backupFileName})
+	c.JSON(http.StatusOK, gin.H{"message": "Leaderboard data restored successfully", "bucket": bucketName, "file": backupFileName, "seasonID": seasonID})
 }
 
 // Run starts the HTTP server on a specific address.
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gbasileGP/pubg-leaderboard/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// archiveKeyPrefix namespaces immutable per-season leaderboard snapshots, separate from the live
+// "leaderboard" key so archival never competes with the current season's TTL-driven refresh.
+const archiveKeyPrefix = "leaderboard:archive:"
+
+// seasonsHistoryKey is the Redis list recording every archived season, most recent first.
+const seasonsHistoryKey = "seasons:history"
+
+// SeasonMeta describes a single archived season, as recorded in the seasons:history list.
+type SeasonMeta struct {
+	SeasonID   string    `json:"seasonID"`
+	ArchivedAt time.Time `json:"archivedAt"`
+}
+
+// SeasonBackupFunc mirrors a just-archived season to durable object storage, backing onto the
+// existing BackupLeaderboardData path. RedisClient has no object-storage client of its own, so this
+// is supplied by whatever wires RedisClient up to that service; ArchiveSeason is a no-op against
+// object storage until one is set via SetSeasonBackup.
+type SeasonBackupFunc func(ctx context.Context, seasonID string) error
+
+// SetSeasonBackup registers the hook ArchiveSeason calls after archiving a season in Redis, so the
+// archive is also mirrored to object storage via the existing backup path.
+func (rc *RedisClient) SetSeasonBackup(fn SeasonBackupFunc) {
+	rc.seasonBackup = fn
+}
+
+// ArchiveSeason copies the final leaderboard for seasonID into an immutable archive key and
+// records the season in seasons:history, so it survives the live leaderboard's TTL-driven expiry
+// and can be retrieved later via GetArchivedLeaderboard. If a SeasonBackupFunc has been registered
+// via SetSeasonBackup, the archive is also mirrored to object storage.
+func (rc *RedisClient) ArchiveSeason(ctx context.Context, seasonID string, leaderboardData *model.LeaderboardResponse) error {
+	leaderboardJSON, err := json.Marshal(leaderboardData)
+	if err != nil {
+		return fmt.Errorf("redisclient - error marshaling archived leaderboard data: %v", err)
+	}
+
+	meta := SeasonMeta{SeasonID: seasonID, ArchivedAt: time.Now().UTC()}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("redisclient - error marshaling season metadata: %v", err)
+	}
+
+	pipe := rc.Client.TxPipeline()
+	pipe.Set(ctx, archiveKeyPrefix+seasonID, leaderboardJSON, 0)
+	pipe.LPush(ctx, seasonsHistoryKey, metaJSON)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redisclient - error archiving season in Redis: %v", err)
+	}
+
+	if rc.seasonBackup != nil {
+		if err := rc.seasonBackup(ctx, seasonID); err != nil {
+			return fmt.Errorf("redisclient - error mirroring archived season to object storage: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ListSeasons returns metadata for every archived season, most recently archived first.
+func (rc *RedisClient) ListSeasons(ctx context.Context) ([]SeasonMeta, error) {
+	entries, err := rc.Client.LRange(ctx, seasonsHistoryKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redisclient - error listing archived seasons: %v", err)
+	}
+
+	seasons := make([]SeasonMeta, 0, len(entries))
+	for _, entry := range entries {
+		var meta SeasonMeta
+		if err := json.Unmarshal([]byte(entry), &meta); err != nil {
+			return nil, fmt.Errorf("redisclient - error unmarshalling season metadata: %v", err)
+		}
+		seasons = append(seasons, meta)
+	}
+
+	return seasons, nil
+}
+
+// GetArchivedLeaderboard retrieves the final leaderboard for a previously archived season.
+func (rc *RedisClient) GetArchivedLeaderboard(ctx context.Context, seasonID string) (*model.LeaderboardResponse, error) {
+	data, err := rc.Client.Get(ctx, archiveKeyPrefix+seasonID).Result()
+	if err == redis.Nil {
+		return nil, ErrCacheMiss
+	} else if err != nil {
+		return nil, err
+	}
+
+	leaderboard := &model.LeaderboardResponse{}
+	if err := json.Unmarshal([]byte(data), leaderboard); err != nil {
+		return nil, fmt.Errorf("redisclient - error unmarshalling archived leaderboard data: %v", err)
+	}
+
+	return leaderboard, nil
+}
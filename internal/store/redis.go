@@ -5,16 +5,55 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"github.com/dgraph-io/ristretto/v2"
 	"github.com/gbasileGP/pubg-leaderboard/internal/model"
 	"github.com/redis/go-redis/v9"
 )
 
 var ErrCacheMiss = errors.New("data not found in Redis")
 
+// leaderboardZSetKey is the ranked ZSET backing leaderboard pagination and around-me lookups.
+const leaderboardZSetKey = "leaderboard:current"
+
+// LeaderboardUpdatedChannel and SeasonUpdatedChannel are pub/sub channels notified whenever their
+// respective cached data changes, so subscribers can push live updates without polling.
+const (
+	LeaderboardUpdatedChannel = "leaderboard.updated"
+	SeasonUpdatedChannel      = "season.updated"
+)
+
+// CacheInvalidationChannel is published to whenever a key cached locally by RedisClient changes,
+// so every pod's in-process cache can evict it instead of relying on its (short) local TTL alone.
+const CacheInvalidationChannel = "cache.invalidated"
+
+// localCacheTTL bounds how long an entry may be served from the in-process cache, as a safety net
+// against invalidation messages that are missed (e.g. during a pod restart).
+const localCacheTTL = 5 * time.Second
+
+// Event is a single message received from a Redis pub/sub subscription.
+type Event struct {
+	Channel string
+	Payload string
+}
+
+// CacheStats reports hit/miss counters for the in-process cache, so operators can tune its size.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
 type RedisClient struct {
 	Client *redis.ClusterClient
+
+	localCache  *ristretto.Cache[string, []byte]
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+
+	// seasonBackup, if set via SetSeasonBackup, mirrors each archived season to object storage.
+	seasonBackup SeasonBackupFunc
 }
 
 // NewRedisClient creates a new Redis Cluster client and checks the connection.
@@ -32,8 +71,34 @@ func NewRedisClient(addrs []string, password string, db int) (*RedisClient, erro
 		return nil, err
 	}
 
+	localCache, err := ristretto.NewCache(&ristretto.Config[string, []byte]{
+		NumCounters: 1e6,
+		MaxCost:     64 << 20, // 64MB
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redisclient - error creating local cache: %v", err)
+	}
+
 	// You can return your RedisClient wrapping the cluster client instead of a regular client
-	return &RedisClient{Client: clusterClient}, nil
+	rc := &RedisClient{Client: clusterClient, localCache: localCache}
+	go rc.watchCacheInvalidations()
+
+	return rc, nil
+}
+
+// watchCacheInvalidations evicts locally-cached keys as soon as another pod invalidates them,
+// keeping every pod's in-process cache coherent without waiting out the local TTL.
+func (rc *RedisClient) watchCacheInvalidations() {
+	events := rc.Subscribe(context.Background(), CacheInvalidationChannel)
+	for event := range events {
+		rc.localCache.Del(event.Payload)
+	}
+}
+
+// CacheStats returns the current hit/miss counters for the in-process cache.
+func (rc *RedisClient) CacheStats() CacheStats {
+	return CacheStats{Hits: rc.cacheHits.Load(), Misses: rc.cacheMisses.Load()}
 }
 
 // Ping tests connectivity to the Redis server.
@@ -41,18 +106,30 @@ func (rc *RedisClient) Ping(ctx context.Context) error {
 	return rc.Client.Ping(ctx).Err()
 }
 
-// GetLeaderboard retrieves the leaderboard data from Redis.
+// GetLeaderboard retrieves the leaderboard data, preferring the local cache over a Redis round-trip.
 func (rc *RedisClient) GetLeaderboard(ctx context.Context) (*model.LeaderboardResponse, error) {
-	data, err := rc.Client.Get(ctx, "leaderboard").Result()
-	if err == redis.Nil {
-		return nil, ErrCacheMiss
-	} else if err != nil {
-		return nil, err
+	const cacheKey = "leaderboard"
+
+	var data string
+	if cached, ok := rc.localCache.Get(cacheKey); ok {
+		rc.cacheHits.Add(1)
+		data = string(cached)
+	} else {
+		rc.cacheMisses.Add(1)
+
+		fetched, err := rc.Client.Get(ctx, cacheKey).Result()
+		if err == redis.Nil {
+			return nil, ErrCacheMiss
+		} else if err != nil {
+			return nil, err
+		}
+		data = fetched
+
+		rc.localCache.SetWithTTL(cacheKey, []byte(data), int64(len(data)), localCacheTTL)
 	}
 
 	leaderboard := &model.LeaderboardResponse{}
-	err = json.Unmarshal([]byte(data), leaderboard)
-	if err != nil {
+	if err := json.Unmarshal([]byte(data), leaderboard); err != nil {
 		return nil, fmt.Errorf("redisclient - error unmarshalling leaderboard data: %v", err)
 	}
 
@@ -73,7 +150,10 @@ func (rc *RedisClient) UpdateLeaderboard(ctx context.Context, leaderboardData *m
 	// Set the entire leaderboard.
 	pipe.Set(ctx, "leaderboard", leaderboardJSON, 10*time.Minute)
 
-	// Store each player's stats in a separate hash.
+	// Rebuild the ranked ZSET from scratch so readers never see a half-updated set.
+	pipe.Del(ctx, leaderboardZSetKey)
+
+	// Store each player's stats in a separate hash and their ranking score in the ZSET.
 	for _, player := range leaderboardData.Included {
 		playerStatsJSON, err := json.Marshal(player.Attributes)
 		if err != nil {
@@ -84,33 +164,104 @@ func (rc *RedisClient) UpdateLeaderboard(ctx context.Context, leaderboardData *m
 		pipe.HSet(ctx, "player_stats:"+player.ID, "stats", playerStatsJSON)
 		// Optionally set an expiration time on each hash.
 		pipe.Expire(ctx, "player_stats:"+player.ID, 10*time.Minute)
+
+		// Add the player to the ranked ZSET, scored by ranking points.
+		pipe.ZAdd(ctx, leaderboardZSetKey, redis.Z{Score: player.Attributes.RankPoints, Member: player.ID})
+
+		// Tell every pod's local cache to evict this player's stale entry.
+		pipe.Publish(ctx, CacheInvalidationChannel, "player_stats:"+player.ID)
 	}
 
+	// Tell every pod's local cache to evict the stale leaderboard entry.
+	pipe.Publish(ctx, CacheInvalidationChannel, "leaderboard")
+
 	// Execute the transaction.
 	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("redisclient - error updating leaderboard in Redis: %v", err)
 	}
 
+	// Notify subscribers that the leaderboard has changed.
+	version := time.Now().UTC().Format(time.RFC3339Nano)
+	if err := rc.Client.Publish(ctx, LeaderboardUpdatedChannel, version).Err(); err != nil {
+		return fmt.Errorf("redisclient - error publishing leaderboard update: %v", err)
+	}
+
 	return nil
 }
 
-// GetPlayerStats retrieves a single player's stats from Redis.
+// GetPlayerStats retrieves a single player's stats, preferring the local cache over a Redis round-trip.
 func (rc *RedisClient) GetPlayerStats(ctx context.Context, playerID string) (*model.PlayerAttribute, error) {
-	data, err := rc.Client.HGet(ctx, "player_stats:"+playerID, "stats").Result()
+	cacheKey := "player_stats:" + playerID
+
+	var data string
+	if cached, ok := rc.localCache.Get(cacheKey); ok {
+		rc.cacheHits.Add(1)
+		data = string(cached)
+	} else {
+		rc.cacheMisses.Add(1)
+
+		fetched, err := rc.Client.HGet(ctx, cacheKey, "stats").Result()
+		if err == redis.Nil {
+			return nil, ErrCacheMiss
+		} else if err != nil {
+			return nil, err
+		}
+		data = fetched
+
+		rc.localCache.SetWithTTL(cacheKey, []byte(data), int64(len(data)), localCacheTTL)
+	}
+
+	playerStats := &model.PlayerAttribute{}
+	if err := json.Unmarshal([]byte(data), playerStats); err != nil {
+		return nil, fmt.Errorf("redisclient - error unmarshalling player stats: %v", err)
+	}
+
+	return playerStats, nil
+}
+
+// GetLeaderboardRange retrieves a page of the leaderboard, ordered highest-ranking-points first.
+func (rc *RedisClient) GetLeaderboardRange(ctx context.Context, start, stop int64) ([]redis.Z, error) {
+	entries, err := rc.Client.ZRevRangeWithScores(ctx, leaderboardZSetKey, start, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redisclient - error fetching leaderboard range: %v", err)
+	}
+
+	return entries, nil
+}
+
+// GetPlayerRank returns a player's zero-based rank within the leaderboard, highest score first.
+func (rc *RedisClient) GetPlayerRank(ctx context.Context, playerID string) (int64, error) {
+	rank, err := rc.Client.ZRevRank(ctx, leaderboardZSetKey, playerID).Result()
+	if err == redis.Nil {
+		return 0, ErrCacheMiss
+	} else if err != nil {
+		return 0, err
+	}
+
+	return rank, nil
+}
+
+// GetAroundPlayer returns the leaderboard entries within radius positions of the given player's rank.
+func (rc *RedisClient) GetAroundPlayer(ctx context.Context, playerID string, radius int64) ([]redis.Z, error) {
+	rank, err := rc.Client.ZRevRank(ctx, leaderboardZSetKey, playerID).Result()
 	if err == redis.Nil {
 		return nil, ErrCacheMiss
 	} else if err != nil {
 		return nil, err
 	}
 
-	playerStats := &model.PlayerAttribute{}
-	err = json.Unmarshal([]byte(data), playerStats)
+	start := rank - radius
+	if start < 0 {
+		start = 0
+	}
+
+	entries, err := rc.Client.ZRevRangeWithScores(ctx, leaderboardZSetKey, start, rank+radius).Result()
 	if err != nil {
-		return nil, fmt.Errorf("redisclient - error unmarshalling player stats: %v", err)
+		return nil, fmt.Errorf("redisclient - error fetching leaderboard around player: %v", err)
 	}
 
-	return playerStats, nil
+	return entries, nil
 }
 
 // GetSeason retrieves the current season identifier from Redis.
@@ -131,13 +282,119 @@ func (rc *RedisClient) GetSeason(ctx context.Context) (*model.SeasonData, error)
 	return season, nil
 }
 
-// UpdateSeason updates the current season data in Redis.
+// UpdateSeason updates the current season data in Redis. If this call rolls the season over (the
+// previously stored season differs from season), the outgoing season's live leaderboard is archived
+// first so it remains retrievable via GetArchivedLeaderboard after this season's data expires.
 func (rc *RedisClient) UpdateSeason(ctx context.Context, season *model.SeasonData) error {
+	previousSeason, err := rc.GetSeason(ctx)
+	if err != nil && !errors.Is(err, ErrCacheMiss) {
+		return fmt.Errorf("redisclient - error reading previous season before rollover: %v", err)
+	}
+	if previousSeason != nil && previousSeason.ID != season.ID {
+		leaderboardData, err := rc.GetLeaderboard(ctx)
+		if err != nil && !errors.Is(err, ErrCacheMiss) {
+			return fmt.Errorf("redisclient - error reading leaderboard before season archival: %v", err)
+		}
+		if leaderboardData != nil {
+			if err := rc.ArchiveSeason(ctx, previousSeason.ID, leaderboardData); err != nil {
+				return fmt.Errorf("redisclient - error archiving previous season: %v", err)
+			}
+		}
+	}
+
 	data, err := json.Marshal(season)
 	if err != nil {
 		return fmt.Errorf("redisclient - error marshalling season data: %v", err)
 	}
 
 	// This sets the season data with a 24-hour expiry, matching the daily season refresh requirement.
-	return rc.Client.Set(ctx, "current_season", data, 24*time.Hour).Err()
+	if err := rc.Client.Set(ctx, "current_season", data, 24*time.Hour).Err(); err != nil {
+		return err
+	}
+
+	// Notify subscribers that the season has changed.
+	version := time.Now().UTC().Format(time.RFC3339Nano)
+	if err := rc.Client.Publish(ctx, SeasonUpdatedChannel, version).Err(); err != nil {
+		return fmt.Errorf("redisclient - error publishing season update: %v", err)
+	}
+
+	return nil
+}
+
+// allowRequestScript atomically increments the fixed-window counter and, only on the first hit of
+// the window, sets its expiry in the same round-trip. Doing this in one script (rather than a
+// separate INCR then EXPIRE) means a transient failure can never leave a counter stuck without a
+// TTL, which would otherwise wedge that key in a permanent rate-limited state.
+var allowRequestScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("EXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("TTL", KEYS[1])}
+`)
+
+// AllowRequest increments the fixed-window request counter for key and reports whether the request
+// is allowed under limit within window. It returns the remaining quota for the current window
+// (negative once the limit is exceeded) and, once exceeded, how many seconds until the window resets.
+func (rc *RedisClient) AllowRequest(ctx context.Context, key string, limit int64, window time.Duration) (remaining, retryAfterSeconds int64, err error) {
+	result, err := allowRequestScript.Run(ctx, rc.Client, []string{key}, int64(window.Seconds())).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("redisclient - error running rate limit script: %v", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("redisclient - unexpected rate limit script result: %v", result)
+	}
+
+	count, ok := values[0].(int64)
+	if !ok {
+		return 0, 0, fmt.Errorf("redisclient - unexpected rate limit counter value: %v", values[0])
+	}
+	ttl, ok := values[1].(int64)
+	if !ok {
+		return 0, 0, fmt.Errorf("redisclient - unexpected rate limit ttl value: %v", values[1])
+	}
+
+	remaining = limit - count
+	if remaining < 0 && ttl > 0 {
+		retryAfterSeconds = ttl
+	}
+
+	return remaining, retryAfterSeconds, nil
+}
+
+// Publish broadcasts a message on the given Redis pub/sub channel.
+func (rc *RedisClient) Publish(ctx context.Context, channel, message string) error {
+	return rc.Client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe listens on the given Redis pub/sub channels and streams messages on the returned channel.
+// The returned channel is closed when ctx is cancelled.
+func (rc *RedisClient) Subscribe(ctx context.Context, channels ...string) <-chan Event {
+	pubsub := rc.Client.Subscribe(ctx, channels...)
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-pubsub.Channel():
+				if !ok {
+					return
+				}
+				select {
+				case events <- Event{Channel: msg.Channel, Payload: msg.Payload}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
 }
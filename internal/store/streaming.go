@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gbasileGP/pubg-leaderboard/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// Defaults for UpdateLeaderboardStreaming's write workers.
+const (
+	DefaultPipeSize   = 500
+	DefaultPipePeriod = 50 * time.Millisecond
+	defaultWorkers    = 4
+)
+
+var (
+	pipelineFlushesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pubg_leaderboard_redis_pipeline_flushes_total",
+		Help: "Total number of player-stat write pipelines flushed to Redis.",
+	})
+	pipelineDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pubg_leaderboard_redis_pipeline_depth",
+		Help:    "Number of commands buffered in each flushed pipeline.",
+		Buckets: prometheus.LinearBuckets(50, 50, 10),
+	})
+	pipelineFlushLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pubg_leaderboard_redis_pipeline_flush_latency_seconds",
+		Help:    "Time taken to execute each flushed pipeline.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// StreamingConfig tunes UpdateLeaderboardStreaming's write workers.
+type StreamingConfig struct {
+	Workers    int
+	PipeSize   int
+	PipePeriod time.Duration
+}
+
+// DefaultStreamingConfig returns the defaults applied to any zero fields of a caller-supplied
+// StreamingConfig.
+func DefaultStreamingConfig() StreamingConfig {
+	return StreamingConfig{Workers: defaultWorkers, PipeSize: DefaultPipeSize, PipePeriod: DefaultPipePeriod}
+}
+
+// playerWrite is a single player's pending Redis write, queued for a streaming worker.
+type playerWrite struct {
+	id    string
+	stats []byte
+	score float64
+}
+
+// UpdateLeaderboardStreaming is a high-fan-out alternative to UpdateLeaderboard: per-player writes
+// are fanned out across worker goroutines, each maintaining its own pipeline that auto-flushes once
+// PipeSize commands are buffered or PipePeriod elapses, instead of building one multi-MB MULTI/EXEC
+// that blocks the cluster slot. The top-level leaderboard key is still written transactionally at
+// the end so readers see a consistent snapshot.
+func (rc *RedisClient) UpdateLeaderboardStreaming(ctx context.Context, leaderboardData *model.LeaderboardResponse, cfg StreamingConfig) error {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.PipeSize <= 0 {
+		cfg.PipeSize = DefaultPipeSize
+	}
+	if cfg.PipePeriod <= 0 {
+		cfg.PipePeriod = DefaultPipePeriod
+	}
+
+	// Rebuild the ranked ZSET from scratch so a player who drops off the leaderboard between
+	// refreshes doesn't keep a stale, ghost entry forever.
+	if err := rc.Client.Del(ctx, leaderboardZSetKey).Err(); err != nil {
+		return fmt.Errorf("redisclient - error clearing leaderboard ZSET: %v", err)
+	}
+
+	writes := make(chan playerWrite, cfg.PipeSize)
+
+	var errMu sync.Mutex
+	var firstErr error
+	reportErr := func(err error) {
+		if err == nil {
+			return
+		}
+		errMu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		errMu.Unlock()
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			rc.runStreamingWorker(ctx, writes, cfg, reportErr)
+		}()
+	}
+
+	go func() {
+		defer close(writes)
+		for _, player := range leaderboardData.Included {
+			playerStatsJSON, err := json.Marshal(player.Attributes)
+			if err != nil {
+				reportErr(fmt.Errorf("redisclient - error marshaling player stats: %v", err))
+				return
+			}
+
+			select {
+			case writes <- playerWrite{id: player.ID, stats: playerStatsJSON, score: player.Attributes.RankPoints}:
+			case <-ctx.Done():
+				// Workers have already stopped draining writes on cancellation; stop producing
+				// rather than blocking forever on a full, abandoned channel.
+				return
+			}
+		}
+	}()
+
+	workers.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// Write the top-level leaderboard key transactionally so readers see a consistent snapshot.
+	leaderboardJSON, err := json.Marshal(leaderboardData)
+	if err != nil {
+		return fmt.Errorf("redisclient - error marshaling entire leaderboard data: %v", err)
+	}
+
+	pipe := rc.Client.TxPipeline()
+	pipe.Set(ctx, "leaderboard", leaderboardJSON, 10*time.Minute)
+	pipe.Publish(ctx, LeaderboardUpdatedChannel, time.Now().UTC().Format(time.RFC3339Nano))
+	pipe.Publish(ctx, CacheInvalidationChannel, "leaderboard")
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redisclient - error updating leaderboard in Redis: %v", err)
+	}
+
+	return nil
+}
+
+// runStreamingWorker drains writes into its own Pipeliner, auto-flushing whenever PipeSize
+// commands are buffered or PipePeriod elapses, whichever comes first.
+func (rc *RedisClient) runStreamingWorker(ctx context.Context, writes <-chan playerWrite, cfg StreamingConfig, reportErr func(error)) {
+	pipe := rc.Client.Pipeline()
+	buffered := 0
+
+	ticker := time.NewTicker(cfg.PipePeriod)
+	defer ticker.Stop()
+
+	flush := func() {
+		if buffered == 0 {
+			return
+		}
+
+		start := time.Now()
+		_, err := pipe.Exec(ctx)
+		pipelineFlushesTotal.Inc()
+		pipelineDepth.Observe(float64(buffered))
+		pipelineFlushLatency.Observe(time.Since(start).Seconds())
+		if err != nil {
+			reportErr(fmt.Errorf("redisclient - error flushing player stats pipeline: %v", err))
+		}
+
+		buffered = 0
+	}
+
+	for {
+		select {
+		case write, ok := <-writes:
+			if !ok {
+				flush()
+				return
+			}
+
+			pipe.HSet(ctx, "player_stats:"+write.id, "stats", write.stats)
+			pipe.Expire(ctx, "player_stats:"+write.id, 10*time.Minute)
+			pipe.ZAdd(ctx, leaderboardZSetKey, redis.Z{Score: write.score, Member: write.id})
+			pipe.Publish(ctx, CacheInvalidationChannel, "player_stats:"+write.id)
+			buffered += 4
+
+			if buffered >= cfg.PipeSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			// Flush whatever is buffered and surface the cancellation so the caller doesn't
+			// proceed to commit the top-level leaderboard key over a partially-written ZSET.
+			flush()
+			reportErr(fmt.Errorf("redisclient - streaming worker stopped: %w", ctx.Err()))
+			return
+		}
+	}
+}